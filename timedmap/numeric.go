@@ -0,0 +1,32 @@
+package timedmap
+
+// Number is the set of numeric types supported by Increment and Decrement.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Increment atomically adds delta to the value stored under k in m and
+// returns the new value along with whether k existed and was unexpired. This
+// closes the TOCTOU race inherent in `v, _ := m.Get(k); m.Set(k, v+delta)`
+// under concurrent writers. If k does not exist or is expired, Increment
+// does nothing and returns the zero value and false.
+func Increment[K comparable, N Number](m *Map[K, N], k K, delta N) (N, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, found := m.m[k]
+	if !found || entry.isExpired() {
+		var zero N
+		return zero, false
+	}
+	entry.v += delta
+	m.m[k] = entry
+	return entry.v, true
+}
+
+// Decrement atomically subtracts delta from the value stored under k in m.
+// See Increment for semantics.
+func Decrement[K comparable, N Number](m *Map[K, N], k K, delta N) (N, bool) {
+	return Increment(m, k, -delta)
+}
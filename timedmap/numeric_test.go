@@ -0,0 +1,76 @@
+package timedmap
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestIncrementExistingKey(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("counter", 10)
+	v, ok := Increment(m, "counter", 5)
+	if !ok || v != 15 {
+		t.Fatalf("expected 15, true; got %d, %v", v, ok)
+	}
+	if cur, _ := m.Get("counter"); cur != 15 {
+		t.Fatalf("expected stored value to be updated to 15, got %d", cur)
+	}
+}
+
+func TestDecrementExistingKey(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("counter", 10)
+	v, ok := Decrement(m, "counter", 4)
+	if !ok || v != 6 {
+		t.Fatalf("expected 6, true; got %d, %v", v, ok)
+	}
+}
+
+func TestIncrementMissingKeyFails(t *testing.T) {
+	m := New[string, int](time.Hour)
+	v, ok := Increment(m, "missing", 1)
+	if ok || v != 0 {
+		t.Fatalf("expected 0, false for a missing key; got %d, %v", v, ok)
+	}
+}
+
+// TestIncrementExpiredKeyFails verifies the "existed and was unexpired"
+// boundary: an expired entry must be treated the same as a missing one.
+func TestIncrementExpiredKeyFails(t *testing.T) {
+	m := New[string, int](5 * time.Millisecond)
+	m.Set("counter", 10)
+	time.Sleep(20 * time.Millisecond)
+	v, ok := Increment(m, "counter", 1)
+	if ok || v != 0 {
+		t.Fatalf("expected 0, false for an expired key; got %d, %v", v, ok)
+	}
+}
+
+func TestIncrementFloat(t *testing.T) {
+	m := New[string, float64](time.Hour)
+	m.Set("total", 1.5)
+	v, ok := Increment(m, "total", 2.25)
+	if !ok || v != 3.75 {
+		t.Fatalf("expected 3.75, true; got %v, %v", v, ok)
+	}
+}
+
+// TestIncrementConcurrentClosesTheRace verifies Increment avoids the
+// classic Get-then-Set TOCTOU race under concurrent writers.
+func TestIncrementConcurrentClosesTheRace(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("counter", 0)
+	var wg sync.WaitGroup
+	for i := 0; i < 200; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			Increment(m, "counter", 1)
+		}()
+	}
+	wg.Wait()
+	if v, _ := m.Get("counter"); v != 200 {
+		t.Fatalf("expected 200 after 200 concurrent increments, got %d", v)
+	}
+}
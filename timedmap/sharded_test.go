@@ -0,0 +1,93 @@
+package timedmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShardedMapSetGetDelete(t *testing.T) {
+	sm := NewSharded[int, int](time.Hour, 4)
+	for i := 0; i < 1000; i++ {
+		sm.Set(i, i*2)
+	}
+	for i := 0; i < 1000; i++ {
+		v, ok := sm.Get(i)
+		if !ok || v != i*2 {
+			t.Fatalf("key %d: got %d, %v", i, v, ok)
+		}
+	}
+	if !sm.Delete(5) {
+		t.Fatal("expected Delete(5) to succeed")
+	}
+	if _, ok := sm.Get(5); ok {
+		t.Fatal("expected key 5 to be gone after Delete")
+	}
+}
+
+func TestShardedMapRoundsUpToPowerOfTwo(t *testing.T) {
+	sm := NewSharded[int, int](time.Hour, 6)
+	if n := len(sm.shards); n != 8 {
+		t.Fatalf("expected 6 shards to round up to 8, got %d", n)
+	}
+}
+
+func TestShardedMapPurge(t *testing.T) {
+	sm := NewSharded[int, int](5*time.Millisecond, 4)
+	for i := 0; i < 100; i++ {
+		sm.Set(i, i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if n := sm.Purge(); n != 100 {
+		t.Fatalf("expected Purge to remove all 100 expired entries across shards, got %d", n)
+	}
+}
+
+func TestShardedMapDump(t *testing.T) {
+	sm := NewSharded[int, int](time.Hour, 4)
+	for i := 0; i < 50; i++ {
+		sm.Set(i, i)
+	}
+	dump := sm.Dump()
+	if len(dump) != 50 {
+		t.Fatalf("expected 50 entries across all shards, got %d", len(dump))
+	}
+	for i := 0; i < 50; i++ {
+		if dump[i] != i {
+			t.Fatalf("dump[%d] = %d, want %d", i, dump[i], i)
+		}
+	}
+}
+
+// TestShardedMapNilInterfaceKey verifies that a ShardedMap keyed on an
+// interface type can store and retrieve a nil key without hashKey's
+// fallback branch panicking.
+func TestShardedMapNilInterfaceKey(t *testing.T) {
+	sm := NewSharded[any, int](time.Hour, 4)
+	sm.Set(nil, 1)
+	v, ok := sm.Get(nil)
+	if !ok || v != 1 {
+		t.Fatalf("expected nil key to round-trip to 1, got %d, %v", v, ok)
+	}
+}
+
+func TestShardedMapResetAndSetExpiration(t *testing.T) {
+	sm := NewSharded[string, int](time.Hour, 4)
+	sm.Set("a", 1)
+	if !sm.SetExpiration("a", time.Now().Add(-time.Minute)) {
+		t.Fatal("expected SetExpiration on an existing key to succeed")
+	}
+	if _, ok := sm.Get("a"); ok {
+		t.Fatal("expected a to be expired after SetExpiration in the past")
+	}
+	if sm.SetExpiration("missing", time.Now()) {
+		t.Fatal("expected SetExpiration on a missing key to fail")
+	}
+
+	sm.Set("b", 2)
+	if !sm.Reset("b") {
+		t.Fatal("expected Reset on an existing key to succeed")
+	}
+	if v, ok := sm.Get("b"); !ok || v != 2 {
+		t.Fatalf("expected b=2 to remain retrievable after Reset, got %d, %v", v, ok)
+	}
+}
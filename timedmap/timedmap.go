@@ -0,0 +1,383 @@
+// Package timedmap provides a key/value store called a `timedmap.Map` that supports "aging out"
+// of its entries based on a defaultExpiration value set at `Map` creation.
+// Values are set and retrieved via `Set()` and `Get()` methods, respectively. If an entry has
+// timed out, it is not retrievable. A `Map` created with `NewWithJanitor` also reclaims expired
+// entries in the background on a fixed interval. Known restrictions include the following:
+//
+// - there is no way to iterate (range) over the contents of the `Map`.
+//
+// - using values that contain mutexes is not safe, as values may be copied in the internal methods.
+package timedmap
+
+import (
+	"encoding/gob"
+	"io"
+	"math"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// DefaultExpiration and NoExpiration are sentinel durations for
+// SetWithExpiration. DefaultExpiration tells SetWithExpiration to use the
+// Map's configured defaultExpiration, while NoExpiration creates an entry
+// that never ages out.
+const (
+	DefaultExpiration time.Duration = 0
+	NoExpiration      time.Duration = -1
+)
+
+// noExpiration is the internal sentinel stored in mapEntry.expiresAt for
+// entries that never age out. It must not collide with any UnixNano value a
+// caller could plausibly construct (including the zero value of time.Time,
+// which callers may pass to SetExpiration), so 0 is not a safe choice here.
+const noExpiration int64 = math.MaxInt64
+
+// mapEntry is a wrapper around a generic value V, adding an expiresAt field
+// storing the entry's absolute expiration as UnixNano. An expiresAt of
+// noExpiration means the entry never expires. Storing an int64 instead of a
+// time.Time shrinks mapEntry and keeps isExpired to a single integer
+// compare, which matters at the sizes benchmarkTimedMapSet/Get exercise.
+type mapEntry[V any] struct {
+	expiresAt int64
+	v         V
+}
+
+// isExpired returns true if the expiration time of the mapEntry
+// has passed, otherwise false. An entry with expiresAt == noExpiration
+// never expires.
+func (me *mapEntry[V]) isExpired() bool {
+	if me.expiresAt == noExpiration {
+		return false
+	}
+	return time.Now().UnixNano() > me.expiresAt
+}
+
+// mapData holds a Map's underlying storage. It is split out from Map so that
+// the janitor goroutine started by NewWithJanitor can hold a reference to the
+// storage without keeping the *Map wrapper (and its runtime.SetFinalizer)
+// reachable, allowing a forgotten Map to still be garbage collected.
+type mapData[K comparable, V any] struct {
+	defaultExpiration time.Duration
+	mu                sync.RWMutex
+	m                 map[K]mapEntry[V]
+	onEvicted         func(k K, v V)
+}
+
+// Map is a generic key/value store that expires entries after a
+// user-defined defaultExpiration period.
+type Map[K comparable, V any] struct {
+	*mapData[K, V]
+	janitor *janitor
+}
+
+// New creates a new Map.
+func New[K comparable, V any](t time.Duration) *Map[K, V] {
+	return &Map[K, V]{mapData: newMapData[K, V](t)}
+}
+
+// NewWithJanitor creates a new Map whose expired entries are also reclaimed
+// in the background by a janitor goroutine that calls Purge every
+// cleanupInterval. A cleanupInterval of 0 starts no janitor, equivalent to
+// calling New. Call Stop to halt the janitor early; otherwise it is stopped
+// automatically via runtime.SetFinalizer when the returned Map is garbage
+// collected.
+func NewWithJanitor[K comparable, V any](defaultExp, cleanupInterval time.Duration) *Map[K, V] {
+	md := newMapData[K, V](defaultExp)
+	mp := &Map[K, V]{mapData: md}
+	if cleanupInterval > 0 {
+		mp.janitor = startJanitor(cleanupInterval, md.Purge)
+		runtime.SetFinalizer(mp, stopJanitor[K, V])
+	}
+	return mp
+}
+
+func newMapData[K comparable, V any](t time.Duration) *mapData[K, V] {
+	return &mapData[K, V]{m: map[K]mapEntry[V]{}, defaultExpiration: t}
+}
+
+// Stop halts the background janitor goroutine started by NewWithJanitor, if
+// one is running. It is safe to call Stop on a Map with no janitor.
+func (m *Map[K, V]) Stop() {
+	if m.janitor != nil {
+		m.janitor.stop()
+		m.janitor = nil
+	}
+}
+
+func stopJanitor[K comparable, V any](m *Map[K, V]) {
+	m.Stop()
+}
+
+// janitor periodically calls a Map's Purge method on a fixed interval until
+// stopped.
+type janitor struct {
+	stopCh chan struct{}
+}
+
+func startJanitor(interval time.Duration, purge func() int) *janitor {
+	j := &janitor{stopCh: make(chan struct{})}
+	go j.run(interval, purge)
+	return j
+}
+
+func (j *janitor) run(interval time.Duration, purge func() int) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			purge()
+		case <-j.stopCh:
+			return
+		}
+	}
+}
+
+func (j *janitor) stop() {
+	close(j.stopCh)
+}
+
+// Get gets a value by key from a Map along with a boolean indicating
+// whether the key was found. If not found, the value will be the zero
+// value. If the entry is found to be expired and an OnEvicted callback is
+// registered, the entry is removed and the callback is invoked.
+func (m *mapData[K, V]) Get(k K) (V, bool) {
+	m.mu.RLock()
+	me, found := m.m[k]
+	hasHook := m.onEvicted != nil
+	m.mu.RUnlock()
+
+	if !found {
+		var zero V
+		return zero, false
+	}
+	if !me.isExpired() {
+		return me.v, true
+	}
+	if hasHook {
+		m.mu.Lock()
+		cur, stillFound := m.m[k]
+		var cb func(K, V)
+		if stillFound && cur.isExpired() {
+			delete(m.m, k)
+			cb = m.onEvicted
+		}
+		m.mu.Unlock()
+		if cb != nil {
+			cb(k, cur.v)
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// OnEvicted registers f as the callback invoked whenever an entry is removed
+// from the Map, whether via an explicit Delete, a Purge, or lazily inside
+// Get when an expired entry is observed. f is invoked outside the Map's
+// lock, so it is safe for f to call back into the Map. Passing nil disables
+// the callback.
+func (m *mapData[K, V]) OnEvicted(f func(k K, v V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEvicted = f
+}
+
+// Set sets a key/value pair in a map (along with a defaultExpiration) if it
+// does not already exist. If the entry exists, Set() will reset the timer value.
+func (m *mapData[K, V]) Set(k K, v V) {
+	m.SetWithExpiration(k, v, DefaultExpiration)
+}
+
+// SetWithExpiration sets a key/value pair in a Map using a per-entry
+// expiration duration d instead of the Map's configured defaultExpiration.
+// Pass DefaultExpiration to use the Map's defaultExpiration, or NoExpiration
+// to create an entry that never ages out.
+func (m *mapData[K, V]) SetWithExpiration(k K, v V, d time.Duration) {
+	var expiresAt int64
+	switch d {
+	case NoExpiration:
+		expiresAt = noExpiration
+	case DefaultExpiration:
+		expiresAt = time.Now().Add(m.defaultExpiration).UnixNano()
+	default:
+		expiresAt = time.Now().Add(d).UnixNano()
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m[k] = mapEntry[V]{v: v, expiresAt: expiresAt}
+}
+
+// Delete deletes an entry from a Map given its key. If the key does not
+// exist in the Map, the function returns false and does nothing. If the entry
+// exists but is expired, the function will return false and the entry will
+// be removed. If the delete is successful, the function will return true.
+// If the entry existed and an OnEvicted callback is registered, the callback
+// is invoked after the Map's lock is released.
+func (m *mapData[K, V]) Delete(k K) bool {
+	m.mu.Lock()
+	entry, found := m.m[k]
+	retval := found && !entry.isExpired()
+	delete(m.m, k) // this is a nop if !found, so safe to do here.
+	cb := m.onEvicted
+	m.mu.Unlock()
+	if found && cb != nil {
+		cb(k, entry.v)
+	}
+	return retval
+}
+
+// SetExpiration sets a custom expiration time for a Map entry given its key. If
+// the key does not exist in the Map, the function returns false and does nothing.
+// A zero expires (time.Time{}) means the entry never expires.
+func (m *mapData[K, V]) SetExpiration(k K, expires time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, found := m.m[k]
+	if !found {
+		return false
+	}
+	if expires.IsZero() {
+		v.expiresAt = noExpiration
+	} else {
+		v.expiresAt = expires.UnixNano()
+	}
+	m.m[k] = v
+	return true
+}
+
+// Reset resets the expiration for a Map entry given its key. If the key
+// does not exist in the Map, the function returns false and does nothing.
+func (m *mapData[K, V]) Reset(k K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, found := m.m[k]
+	if !found {
+		return false
+	}
+	v.expiresAt = time.Now().Add(m.defaultExpiration).UnixNano()
+	m.m[k] = v
+	return true
+}
+
+// Purge deletes all expired entries from the Map and returns
+// the number of deleted entries. If an OnEvicted callback is registered, it
+// is invoked for each deleted entry after the Map's lock is released.
+// Purge should be called sparingly as it locks the Map
+// for the duration of the iteration.
+func (m *mapData[K, V]) Purge() int {
+	type evictedEntry struct {
+		k K
+		v V
+	}
+	var i int
+	var evicted []evictedEntry
+	m.mu.Lock()
+	cb := m.onEvicted
+	for k, v := range m.m {
+		if v.isExpired() {
+			i++
+			delete(m.m, k)
+			if cb != nil {
+				evicted = append(evicted, evictedEntry{k, v.v})
+			}
+		}
+	}
+	m.mu.Unlock()
+	for _, e := range evicted {
+		cb(e.k, e.v)
+	}
+	return i
+}
+
+// Dump returns a standard map containing the unexpired
+// values within the Map.
+func (m *mapData[K, V]) Dump() map[K]V {
+	dumpm := map[K]V{}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for k, v := range m.m {
+		if !v.isExpired() {
+			dumpm[k] = v.v
+		}
+	}
+	return dumpm
+}
+
+// gobEntry is the on-disk representation of a mapEntry used by Save and
+// Load. Unlike mapEntry, its fields are exported so encoding/gob can see
+// them.
+type gobEntry[K comparable, V any] struct {
+	Key       K
+	Value     V
+	ExpiresAt time.Time
+}
+
+// Save writes the Map's live entries, including their absolute expiresAt
+// timestamps, to w using encoding/gob. If V (or K) is an interface type,
+// callers must gob.Register the concrete types stored in the Map before
+// calling Save or Load.
+func (m *mapData[K, V]) Save(w io.Writer) error {
+	m.mu.RLock()
+	entries := make([]gobEntry[K, V], 0, len(m.m))
+	for k, v := range m.m {
+		var expiresAt time.Time
+		if v.expiresAt != noExpiration {
+			expiresAt = time.Unix(0, v.expiresAt)
+		}
+		entries = append(entries, gobEntry[K, V]{Key: k, Value: v.v, ExpiresAt: expiresAt})
+	}
+	m.mu.RUnlock()
+	return gob.NewEncoder(w).Encode(entries)
+}
+
+// SaveFile writes the Map's live entries to the file at path, creating it if
+// it does not exist or truncating it if it does. See Save for details.
+func (m *mapData[K, V]) SaveFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Save(f)
+}
+
+// Load reads entries previously written by Save from r and merges them into
+// the Map. Entries whose expiresAt has already passed are skipped, and
+// existing unexpired keys in the Map are left untouched rather than
+// clobbered by the loaded snapshot.
+func (m *mapData[K, V]) Load(r io.Reader) error {
+	var entries []gobEntry[K, V]
+	if err := gob.NewDecoder(r).Decode(&entries); err != nil {
+		return err
+	}
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, e := range entries {
+		if !e.ExpiresAt.IsZero() && e.ExpiresAt.Before(now) {
+			continue
+		}
+		if cur, found := m.m[e.Key]; found && !cur.isExpired() {
+			continue
+		}
+		expiresAt := noExpiration
+		if !e.ExpiresAt.IsZero() {
+			expiresAt = e.ExpiresAt.UnixNano()
+		}
+		m.m[e.Key] = mapEntry[V]{v: e.Value, expiresAt: expiresAt}
+	}
+	return nil
+}
+
+// LoadFile reads entries previously written by SaveFile from the file at
+// path and merges them into the Map. See Load for details.
+func (m *mapData[K, V]) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return m.Load(f)
+}
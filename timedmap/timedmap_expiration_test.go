@@ -0,0 +1,32 @@
+package timedmap
+
+import (
+	"testing"
+	"time"
+)
+
+// TestExpirationSentinelNotEpoch guards against the internal "never
+// expires" sentinel colliding with a caller-constructed UnixNano value. An
+// entry explicitly set to expire at the Unix epoch must be immediately
+// expired, not immortal.
+func TestExpirationSentinelNotEpoch(t *testing.T) {
+	m := New[string, int](time.Minute)
+	m.Set("a", 1)
+	m.SetExpiration("a", time.Unix(0, 0))
+	if v, ok := m.Get("a"); ok {
+		t.Fatalf("expected entry set to expire at the Unix epoch to be expired, got %d", v)
+	}
+}
+
+// TestSetExpirationZeroNeverExpires verifies that passing the zero
+// time.Time to SetExpiration means "never expires", matching the
+// NoExpiration sentinel used by SetWithExpiration.
+func TestSetExpirationZeroNeverExpires(t *testing.T) {
+	m := New[string, int](time.Nanosecond)
+	m.Set("a", 1)
+	m.SetExpiration("a", time.Time{})
+	time.Sleep(time.Millisecond)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected entry set to expire at the zero time.Time to never expire, got %d, %v", v, ok)
+	}
+}
@@ -0,0 +1,33 @@
+package timedmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWithJanitorPurgesInBackground(t *testing.T) {
+	m := NewWithJanitor[string, int](10*time.Millisecond, 10*time.Millisecond)
+	defer m.Stop()
+	m.Set("a", 1)
+	time.Sleep(100 * time.Millisecond)
+	if n := len(m.Dump()); n != 0 {
+		t.Fatalf("expected janitor to have purged the expired entry, got %d remaining", n)
+	}
+}
+
+func TestNewWithJanitorZeroIntervalStartsNoJanitor(t *testing.T) {
+	m := NewWithJanitor[string, int](time.Hour, 0)
+	defer m.Stop()
+	if m.janitor != nil {
+		t.Fatal("expected a zero cleanupInterval to start no janitor")
+	}
+}
+
+func TestStopIsIdempotentAndSafeWithoutJanitor(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Stop() // no janitor running; must not panic
+
+	m2 := NewWithJanitor[string, int](time.Hour, 5*time.Millisecond)
+	m2.Stop()
+	m2.Stop() // stopping twice must not panic
+}
@@ -0,0 +1,39 @@
+package timedmap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSetWithExpirationDefault(t *testing.T) {
+	m := New[string, int](5 * time.Millisecond)
+	m.SetWithExpiration("a", 1, DefaultExpiration)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1 immediately after set, got %d, %v", v, ok)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("expected a to have expired using the Map's default expiration")
+	}
+}
+
+func TestSetWithExpirationNoExpiration(t *testing.T) {
+	m := New[string, int](time.Millisecond)
+	m.SetWithExpiration("a", 1, NoExpiration)
+	time.Sleep(10 * time.Millisecond)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1 to never expire, got %d, %v", v, ok)
+	}
+}
+
+func TestSetWithExpirationOverride(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.SetWithExpiration("a", 1, 5*time.Millisecond)
+	if v, ok := m.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1 immediately after set, got %d, %v", v, ok)
+	}
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("expected a to have expired using the per-entry override rather than the Map's long default")
+	}
+}
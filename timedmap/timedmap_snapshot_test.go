@@ -0,0 +1,88 @@
+package timedmap
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("a", 1)
+	m.SetWithExpiration("b", 2, NoExpiration)
+
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	m2 := New[string, int](time.Hour)
+	if err := m2.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if v, ok := m2.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1, got %d, %v", v, ok)
+	}
+	if v, ok := m2.Get("b"); !ok || v != 2 {
+		t.Fatalf("expected b=2, got %d, %v", v, ok)
+	}
+}
+
+func TestLoadSkipsAlreadyExpiredEntries(t *testing.T) {
+	m := New[string, int](5 * time.Millisecond)
+	m.Set("a", 1)
+	time.Sleep(20 * time.Millisecond) // a is now expired but still present in m.m
+
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	m2 := New[string, int](time.Hour)
+	if err := m2.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if _, ok := m2.Get("a"); ok {
+		t.Fatal("expected an already-expired entry to be skipped on Load")
+	}
+}
+
+func TestLoadDoesNotClobberFresherKeys(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("a", 1)
+	var buf bytes.Buffer
+	if err := m.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+
+	m2 := New[string, int](time.Hour)
+	m2.Set("a", 99) // fresher, unexpired live value
+	if err := m2.Load(&buf); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if v, _ := m2.Get("a"); v != 99 {
+		t.Fatalf("expected the existing unexpired key to be preserved, got %d", v)
+	}
+}
+
+func TestSaveFileLoadFileRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.gob")
+	m := New[string, int](time.Hour)
+	m.Set("a", 1)
+	if err := m.SaveFile(path); err != nil {
+		t.Fatalf("SaveFile: %v", err)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Fatalf("expected snapshot file to exist: %v", err)
+	}
+
+	m2 := New[string, int](time.Hour)
+	if err := m2.LoadFile(path); err != nil {
+		t.Fatalf("LoadFile: %v", err)
+	}
+	if v, ok := m2.Get("a"); !ok || v != 1 {
+		t.Fatalf("expected a=1, got %d, %v", v, ok)
+	}
+}
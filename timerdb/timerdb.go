@@ -0,0 +1,214 @@
+// Package timerdb provides a key/value store called a `timerdb.Map` that supports "aging out"
+// or expiration of its entries based on a timeout value set at `Map` creation.
+// Values are set and retrieved via `Set()` and `Get` methods, respectively. If an entry has
+// timed out, it is not retrievable. `SetWithExpiration` can override an individual entry's
+// expiration, using the `DefaultExpiration` or `NoExpiration` sentinels in place of a concrete
+// duration.
+// Known restrictions include the following:
+//
+// - there is no way to iterate (range) over the contents of the `Map`.
+//
+// - using values that contain mutexes is not safe, as values may internally be copied.
+package timerdb
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+// DefaultExpiration and NoExpiration are sentinel durations for
+// SetWithExpiration. DefaultExpiration tells SetWithExpiration to use the
+// Map's configured timeout, while NoExpiration creates an entry that never
+// ages out.
+const (
+	DefaultExpiration time.Duration = 0
+	NoExpiration      time.Duration = -1
+)
+
+// noExpiration is the internal sentinel stored in mapEntry.expiration for
+// entries that never age out. It must not collide with any UnixNano value a
+// caller could plausibly construct (including the zero value of time.Time,
+// which callers may pass to SetExpiration), so 0 is not a safe choice here.
+const noExpiration int64 = math.MaxInt64
+
+// mapEntry is a wrapper around a generic value V, adding an expiration field
+// storing the entry's absolute expiration as UnixNano. An expiration of
+// noExpiration means the entry never expires. Storing an int64 instead of a
+// time.Time shrinks mapEntry and keeps isExpired to a single integer
+// compare.
+type mapEntry[V any] struct {
+	expiration int64
+	v          V
+}
+
+// isExpired returns true if the expiration time of the mapEntry
+// has passed, otherwise false. An entry with expiration == noExpiration
+// never expires.
+func (me *mapEntry[V]) isExpired() bool {
+	if me.expiration == noExpiration {
+		return false
+	}
+	return time.Now().UnixNano() > me.expiration
+}
+
+// Map is a generic key/value store that expires entries after a
+// user-defined timeout period.
+type Map[K comparable, V any] struct {
+	timeout   time.Duration
+	mu        sync.RWMutex
+	m         map[K]mapEntry[V]
+	onEvicted func(k K, v V)
+}
+
+// New creates a new Map.
+func New[K comparable, V any](t time.Duration) *Map[K, V] {
+	m := map[K]mapEntry[V]{}
+	return &Map[K, V]{m: m, timeout: t}
+}
+
+// Get gets a value by key from a Map along with a boolean indicating
+// whether the key was found. If not found, the value will be the zero
+// value. If the entry is found to be expired and an OnEvicted callback is
+// registered, the entry is removed and the callback is invoked.
+func (m *Map[K, V]) Get(k K) (V, bool) {
+	m.mu.RLock()
+	me, found := m.m[k]
+	hasHook := m.onEvicted != nil
+	m.mu.RUnlock()
+
+	if !found {
+		var zero V
+		return zero, false
+	}
+	if !me.isExpired() {
+		return me.v, true
+	}
+	if hasHook {
+		m.mu.Lock()
+		cur, stillFound := m.m[k]
+		var cb func(K, V)
+		if stillFound && cur.isExpired() {
+			delete(m.m, k)
+			cb = m.onEvicted
+		}
+		m.mu.Unlock()
+		if cb != nil {
+			cb(k, cur.v)
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// OnEvicted registers f as the callback invoked whenever an entry is removed
+// from the Map, whether via an explicit Delete, a Purge, or lazily inside
+// Get when an expired entry is observed. f is invoked outside the Map's
+// lock, so it is safe for f to call back into the Map. Passing nil disables
+// the callback.
+func (m *Map[K, V]) OnEvicted(f func(k K, v V)) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.onEvicted = f
+}
+
+// Set sets a key/value pair in a map along with a timeout if it does not already exist.
+// If the entry exists, Set() will reset the timer value.
+func (m *Map[K, V]) Set(k K, v V) {
+	m.SetWithExpiration(k, v, DefaultExpiration)
+}
+
+// SetWithExpiration sets a key/value pair in a Map using a per-entry
+// timeout duration d instead of the Map's configured timeout. Pass
+// DefaultExpiration to use the Map's timeout, or NoExpiration to create an
+// entry that never ages out.
+func (m *Map[K, V]) SetWithExpiration(k K, v V, d time.Duration) {
+	var expires int64
+	switch d {
+	case NoExpiration:
+		expires = noExpiration
+	case DefaultExpiration:
+		expires = time.Now().Add(m.timeout).UnixNano()
+	default:
+		expires = time.Now().Add(d).UnixNano()
+	}
+	m.mu.Lock()
+	m.m[k] = mapEntry[V]{v: v, expiration: expires}
+	m.mu.Unlock()
+}
+
+// Delete deletes an entry from a Map given its key. If the key does not
+// exist in the Map, the function returns false and does nothing. If the entry
+// exists but is expired, the function will return false and the entry will
+// be removed. If the delete is successful, the function will return true.
+// If the entry existed and an OnEvicted callback is registered, the callback
+// is invoked after the Map's lock is released.
+func (m *Map[K, V]) Delete(k K) bool {
+	m.mu.Lock()
+	entry, found := m.m[k]
+	retval := found && !entry.isExpired()
+	delete(m.m, k) // this is a nop if !found, so safe to do here.
+	cb := m.onEvicted
+	m.mu.Unlock()
+	if found && cb != nil {
+		cb(k, entry.v)
+	}
+	return retval
+}
+
+// SetExpiration sets a custom expiration time for a Map entry given its key. If
+// the key does not exist in the Map, the function returns false and does nothing.
+// A zero expires (time.Time{}) means the entry never expires.
+func (m *Map[K, V]) SetExpiration(k K, expires time.Time) bool {
+	m.mu.RLock()
+	v, found := m.m[k]
+	m.mu.RUnlock()
+	if !found {
+		return false
+	}
+	if expires.IsZero() {
+		v.expiration = noExpiration
+	} else {
+		v.expiration = expires.UnixNano()
+	}
+	m.mu.Lock()
+	m.m[k] = v
+	m.mu.Unlock()
+	return true
+}
+
+// Reset resets the timeout for a Map entry given its key. If the key
+// does not exist in the Map, the function returns false and does nothing.
+func (m *Map[K, V]) Reset(k K) bool {
+	return m.SetExpiration(k, time.Now().Add(m.timeout))
+}
+
+// Purge deletes all expired entries from the Map and returns
+// the number of deleted entries. If an OnEvicted callback is registered, it
+// is invoked for each deleted entry after the Map's lock is released.
+// Purge should be called sparingly as it locks the Map
+// while it iterates over it.
+func (m *Map[K, V]) Purge() int {
+	type evictedEntry struct {
+		k K
+		v V
+	}
+	var i int
+	var evicted []evictedEntry
+	m.mu.Lock()
+	cb := m.onEvicted
+	for k, v := range m.m {
+		if v.isExpired() {
+			i++
+			delete(m.m, k)
+			if cb != nil {
+				evicted = append(evicted, evictedEntry{k, v.v})
+			}
+		}
+	}
+	m.mu.Unlock()
+	for _, e := range evicted {
+		cb(e.k, e.v)
+	}
+	return i
+}
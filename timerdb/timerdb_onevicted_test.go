@@ -0,0 +1,82 @@
+package timerdb
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOnEvictedFiresOnDelete(t *testing.T) {
+	var gotKey string
+	var gotVal int
+	m := New[string, int](time.Hour)
+	m.OnEvicted(func(k string, v int) { gotKey, gotVal = k, v })
+	m.Set("a", 1)
+	m.Delete("a")
+	if gotKey != "a" || gotVal != 1 {
+		t.Fatalf("expected callback for a=1, got %q=%d", gotKey, gotVal)
+	}
+}
+
+func TestOnEvictedFiresOnGetOfExpiredEntry(t *testing.T) {
+	var gotKey string
+	var gotVal int
+	m := New[string, int](5 * time.Millisecond)
+	m.OnEvicted(func(k string, v int) { gotKey, gotVal = k, v })
+	m.Set("a", 1)
+	time.Sleep(20 * time.Millisecond)
+	if _, ok := m.Get("a"); ok {
+		t.Fatal("expected a to be expired")
+	}
+	if gotKey != "a" || gotVal != 1 {
+		t.Fatalf("expected callback for a=1 from lazy Get eviction, got %q=%d", gotKey, gotVal)
+	}
+}
+
+func TestOnEvictedFiresOnPurge(t *testing.T) {
+	evicted := map[string]int{}
+	m := New[string, int](5 * time.Millisecond)
+	m.OnEvicted(func(k string, v int) { evicted[k] = v })
+	m.Set("a", 1)
+	m.Set("b", 2)
+	time.Sleep(20 * time.Millisecond)
+	if n := m.Purge(); n != 2 {
+		t.Fatalf("expected 2 purged entries, got %d", n)
+	}
+	if evicted["a"] != 1 || evicted["b"] != 2 {
+		t.Fatalf("expected callback for both a and b, got %v", evicted)
+	}
+}
+
+// TestOnEvictedCalledOutsideLock verifies the callback is invoked outside
+// the Map's lock, so it is safe for the callback to call back into the Map
+// (e.g. to re-Set the evicted key) without deadlocking.
+func TestOnEvictedCalledOutsideLock(t *testing.T) {
+	m := New[string, int](5 * time.Millisecond)
+	m.OnEvicted(func(k string, v int) {
+		// Would deadlock here if called while the Map's lock is still held.
+		m.SetWithExpiration(k, v+1, NoExpiration)
+	})
+	m.Set("a", 1)
+	time.Sleep(20 * time.Millisecond)
+
+	done := make(chan bool)
+	go func() {
+		_, _ = m.Get("a")
+		done <- true
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Get deadlocked: OnEvicted callback must run outside the Map's lock")
+	}
+
+	if v, ok := m.Get("a"); !ok || v != 2 {
+		t.Fatalf("expected callback's re-Set to have taken effect, got %d, %v", v, ok)
+	}
+}
+
+func TestOnEvictedNotCalledWhenNil(t *testing.T) {
+	m := New[string, int](time.Hour)
+	m.Set("a", 1)
+	m.Delete("a") // must not panic with no callback registered
+}
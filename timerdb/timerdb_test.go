@@ -0,0 +1,48 @@
+package timerdb
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+)
+
+func benchmarkTimerdbMapSet(size int, b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		m := New[int, int](time.Duration(30 * time.Second))
+		for i := 0; i < size; i++ {
+			m.Set(i, i)
+		}
+	}
+}
+
+func benchmarkTimerdbMapGet(size int, b *testing.B) {
+	m := New[int, int](time.Duration(30 * time.Second))
+	accesses := make([]int, size*2)
+	for i := 0; i < size; i++ {
+		m.Set(i, i)
+		accesses[i] = i
+		accesses[size+i] = size + i
+	}
+	rand.Seed(time.Now().UnixNano())
+	rand.Shuffle(len(accesses), func(i, j int) { accesses[i], accesses[j] = accesses[j], accesses[i] })
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		for i := range accesses {
+			_, _ = m.Get(i)
+		}
+	}
+}
+
+func BenchmarkTimerdbMapSet1(b *testing.B) { benchmarkTimerdbMapSet(10, b) }
+func BenchmarkTimerdbMapSet2(b *testing.B) { benchmarkTimerdbMapSet(100, b) }
+func BenchmarkTimerdbMapSet3(b *testing.B) { benchmarkTimerdbMapSet(1000, b) }
+func BenchmarkTimerdbMapSet4(b *testing.B) { benchmarkTimerdbMapSet(10000, b) }
+func BenchmarkTimerdbMapSet5(b *testing.B) { benchmarkTimerdbMapSet(100000, b) }
+func BenchmarkTimerdbMapSet6(b *testing.B) { benchmarkTimerdbMapSet(1000000, b) }
+
+func BenchmarkTimerdbMapGet1(b *testing.B) { benchmarkTimerdbMapGet(10, b) }
+func BenchmarkTimerdbMapGet2(b *testing.B) { benchmarkTimerdbMapGet(100, b) }
+func BenchmarkTimerdbMapGet3(b *testing.B) { benchmarkTimerdbMapGet(1000, b) }
+func BenchmarkTimerdbMapGet4(b *testing.B) { benchmarkTimerdbMapGet(10000, b) }
+func BenchmarkTimerdbMapGet5(b *testing.B) { benchmarkTimerdbMapGet(100000, b) }
+func BenchmarkTimerdbMapGet6(b *testing.B) { benchmarkTimerdbMapGet(1000000, b) }